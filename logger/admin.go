@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AdminHandler returns an http.Handler exposing the threadIds and
+// sessions currently buffered in l, for operator introspection.
+//
+//	GET    /threads      list every in-flight thread with a summary
+//	GET    /threads/{id} dump the pending entries for one thread
+//	DELETE /threads/{id} force-flush an abandoned thread via end()
+//
+// authorize is called on every request before anything else; if it
+// returns false, or is nil, the handler responds 403 without touching
+// l's state. A nil authorize therefore denies every request rather
+// than exposing the dump and force-end routes to anyone who can reach
+// this handler — pass a callback that checks, e.g., a bearer token or
+// remote address so this can be safely mounted in production.
+func (l *Logger) AdminHandler(authorize func(*http.Request) bool) http.Handler {
+	return &adminHandler{logger: l, authorize: authorize}
+}
+
+type adminHandler struct {
+	logger    *Logger
+	authorize func(*http.Request) bool
+}
+
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if h.authorize == nil || !h.authorize(r) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/threads"), "/")
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		h.list(w)
+	case id != "" && r.Method == http.MethodGet:
+		h.get(w, r, id)
+	case id != "" && r.Method == http.MethodDelete:
+		h.forceEnd(w, r, id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+type threadSummary struct {
+	ThreadId string    `json:"thread_id"`
+	Entries  int       `json:"entries"`
+	Opened   time.Time `json:"opened"`
+	Age      string    `json:"age"`
+}
+
+func (h *adminHandler) summarize(threadId string, n int) threadSummary {
+	var opened time.Time
+	if t, ok := h.logger.opened.Load(threadId); ok {
+		opened = t.(time.Time)
+	}
+	return threadSummary{
+		ThreadId: threadId,
+		Entries:  n,
+		Opened:   opened,
+		Age:      time.Since(opened).String(),
+	}
+}
+
+func (h *adminHandler) list(w http.ResponseWriter) {
+	var threads []threadSummary
+	h.logger.logs.Range(func(k, v interface{}) bool {
+		buf := v.(*threadBuf)
+		buf.mu.Lock()
+		n := len(buf.entries)
+		buf.mu.Unlock()
+		threads = append(threads, h.summarize(k.(string), n))
+		return true
+	})
+	writeJSON(w, threads)
+}
+
+func (h *adminHandler) get(w http.ResponseWriter, r *http.Request, threadId string) {
+	v, ok := h.logger.logs.Load(threadId)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	buf := v.(*threadBuf)
+	buf.mu.Lock()
+	entries := append([]*Entry(nil), buf.entries...)
+	buf.mu.Unlock()
+	writeJSON(w, entries)
+}
+
+// forceEnd flushes an abandoned thread the same way End does, except
+// the resulting Log carries Reason "forced" rather than a real status
+// and duration, since none were ever reported. It looks up the
+// thread's kind and route/name from threadMeta so a forced session end
+// is still reported as a session under its own name, rather than
+// mislabeled as an empty request.
+func (h *adminHandler) forceEnd(w http.ResponseWriter, r *http.Request, threadId string) {
+	if _, ok := h.logger.logs.Load(threadId); !ok {
+		http.NotFound(w, r)
+		return
+	}
+	kind, route := kindRequest, ""
+	if m, ok := h.logger.threadMeta.Load(threadId); ok {
+		meta := m.(threadMeta)
+		kind, route = meta.kind, meta.route
+	}
+	h.logger.end(kind, threadId, route, 0, 0, "forced")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminHandlerDeniesByDefault(t *testing.T) {
+	l := &Logger{}
+	h := l.AdminHandler(nil)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/threads", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("nil authorize: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestAdminHandlerDeniesWhenAuthorizeRejects(t *testing.T) {
+	l := &Logger{}
+	h := l.AdminHandler(func(*http.Request) bool { return false })
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/threads", nil))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("rejecting authorize: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestAdminHandlerForceEndReportsSessionKind(t *testing.T) {
+	var logs []Log
+	l := &Logger{OnLogEvent: func(log Log) { logs = append(logs, log) }}
+
+	sess := l.Sess("import-job")
+	sess.Info("started")
+	threadId := sess.id
+
+	h := l.AdminHandler(func(*http.Request) bool { return true })
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/threads/"+threadId, nil))
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("force-end status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("got %d Logs, want 1", len(logs))
+	}
+	if logs[0].Kind != kindSession {
+		t.Fatalf("Kind = %v, want %v", logs[0].Kind, kindSession)
+	}
+	if logs[0].Route != "import-job" {
+		t.Fatalf("Route = %q, want %q", logs[0].Route, "import-job")
+	}
+	if logs[0].Reason != "forced" {
+		t.Fatalf("Reason = %q, want %q", logs[0].Reason, "forced")
+	}
+}
+
+func TestAdminHandlerForceEndSuppressesEmptySession(t *testing.T) {
+	var logs []Log
+	l := &Logger{OnLogEvent: func(log Log) { logs = append(logs, log) }}
+
+	sess := l.Sess("import-job")
+	// Register a buffer for the session without logging anything
+	// into it, then force-end it through the admin handler's DELETE
+	// route.
+	l.logs.Store(sess.id, &threadBuf{})
+
+	h := l.AdminHandler(func(*http.Request) bool { return true })
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/threads/"+sess.id, nil))
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("force-end status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if len(logs) != 0 {
+		t.Fatalf("got %d Logs for an empty session, want 0: %+v", len(logs), logs)
+	}
+}
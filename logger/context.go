@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+)
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying threadId, so code that
+// already threads a context.Context can propagate the logger's
+// thread identity through it instead of passing reqId by hand.
+func NewContext(ctx context.Context, threadId string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, threadId)
+}
+
+// FromContext returns the threadId previously attached to ctx via
+// NewContext, if any.
+func FromContext(ctx context.Context) (threadId string, ok bool) {
+	threadId, ok = ctx.Value(ctxKey{}).(string)
+	return threadId, ok
+}
+
+func (l *Logger) InfoCtx(ctx context.Context, msg string) *Entry {
+	threadId, _ := FromContext(ctx)
+	return l.logEntry(levelInfo, threadId, msg)
+}
+func (l *Logger) ErrorCtx(ctx context.Context, msg string) *Entry {
+	threadId, _ := FromContext(ctx)
+	return l.logEntry(levelError, threadId, msg)
+}
+func (l *Logger) DebugCtx(ctx context.Context, msg string) *Entry {
+	threadId, _ := FromContext(ctx)
+	return l.logEntry(levelDebug, threadId, msg)
+}
+
+func (l *Logger) InfoFCtx(ctx context.Context, format string, a ...interface{}) *Entry {
+	threadId, _ := FromContext(ctx)
+	return l.logEntry(levelInfo, threadId, fmt.Sprintf(format, a...))
+}
+func (l *Logger) ErrorFCtx(ctx context.Context, format string, a ...interface{}) *Entry {
+	threadId, _ := FromContext(ctx)
+	return l.logEntry(levelError, threadId, fmt.Sprintf(format, a...))
+}
+func (l *Logger) DebugFCtx(ctx context.Context, format string, a ...interface{}) *Entry {
+	threadId, _ := FromContext(ctx)
+	return l.logEntry(levelDebug, threadId, fmt.Sprintf(format, a...))
+}
+
+// EndCtx is End for callers that only have the request's
+// context.Context to hand rather than its reqId.
+func (l *Logger) EndCtx(ctx context.Context, route string, status, duration int) {
+	threadId, _ := FromContext(ctx)
+	l.end(kindRequest, threadId, route, status, duration, "")
+}
+
+// WatchContext registers ctx against threadId so that if ctx is done
+// before End or EndCtx is called — its deadline is exceeded or it is
+// cancelled — the logger flushes whatever entries have accumulated so
+// far instead of losing them silently. The flushed Log's Reason is set
+// to "timeout" or "cancelled" accordingly. It spawns one goroutine that
+// exits once ctx is done.
+func (l *Logger) WatchContext(ctx context.Context, threadId, route string) {
+	l.watch(ctx, kindRequest, threadId, route)
+}
+
+func (l *Logger) watch(ctx context.Context, kind logKind, threadId, route string) {
+	l.watched.Store(threadId, new(int32))
+	go func() {
+		<-ctx.Done()
+		// end() itself claims threadId via the flag registered above,
+		// so if End/EndCtx/Session.End already won that race this
+		// call is a no-op.
+		reason := "cancelled"
+		if ctx.Err() == context.DeadlineExceeded {
+			reason = "timeout"
+		}
+		l.end(kind, threadId, route, 0, 0, reason)
+	}()
+}
+
+// WatchContext registers ctx against the session so that if ctx is done
+// before End is called, the logger flushes whatever entries have
+// accumulated instead of losing them silently.
+func (s *Session) WatchContext(ctx context.Context) {
+	s.logger.watch(ctx, kindSession, s.id, s.name)
+}
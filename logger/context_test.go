@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFromContextRoundTrip(t *testing.T) {
+	ctx := NewContext(context.Background(), "thread-1")
+
+	got, ok := FromContext(ctx)
+	if !ok || got != "thread-1" {
+		t.Fatalf("FromContext() = %q, %v; want %q, true", got, ok, "thread-1")
+	}
+
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatal("FromContext() on an untagged context returned ok = true")
+	}
+}
+
+func TestWatchContextDoesNotDoubleEmit(t *testing.T) {
+	var mu sync.Mutex
+	var logs []Log
+
+	l := &Logger{
+		OnLogEvent: func(log Log) {
+			mu.Lock()
+			logs = append(logs, log)
+			mu.Unlock()
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	l.Info("thread-1", "working")
+	l.WatchContext(ctx, "thread-1", "/widgets")
+
+	// Race End against the context being cancelled at roughly the
+	// same moment; exactly one of them must win.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		l.End("thread-1", "/widgets", 200, 5)
+	}()
+	go func() {
+		defer wg.Done()
+		cancel()
+	}()
+	wg.Wait()
+
+	// Give the watcher goroutine a moment to observe ctx.Done if it
+	// was going to lose the race anyway.
+	time.Sleep(20 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(logs) != 1 {
+		t.Fatalf("got %d Logs emitted, want exactly 1: %+v", len(logs), logs)
+	}
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Encoder writes a single Log to w in some wire or display format.
+type Encoder interface {
+	Encode(w io.Writer, log Log) error
+}
+
+// JSONEncoder writes each Log as a single line of JSON.
+type JSONEncoder struct{}
+
+func (JSONEncoder) Encode(w io.Writer, log Log) error {
+	return json.NewEncoder(w).Encode(log)
+}
+
+// LogfmtEncoder writes one logfmt-style line per entry, repeating the
+// Log's own fields on every line so each line stands alone.
+type LogfmtEncoder struct{}
+
+func (LogfmtEncoder) Encode(w io.Writer, log Log) error {
+
+	// A completed Log with no entries still has a Status/Duration/
+	// Route worth reporting (the common "nothing went wrong" case),
+	// so it needs its own line rather than silently producing no
+	// output, same as ConsoleEncoder's header line.
+	if len(log.Entries) == 0 {
+		_, err := fmt.Fprintf(w,
+			"thread_id=%s kind=%s route=%q status=%d duration=%d\n",
+			log.ThreadId, log.Kind, log.Route, log.Status, log.Duration,
+		)
+		return err
+	}
+
+	for _, e := range log.Entries {
+		if _, err := fmt.Fprintf(w,
+			"thread_id=%s kind=%s route=%q status=%d duration=%d level=%s function=%s file=%s line=%d msg=%q",
+			log.ThreadId, log.Kind, log.Route, log.Status, log.Duration,
+			e.Level, e.Function, e.File, e.Line, e.Message,
+		); err != nil {
+			return err
+		}
+		for _, kv := range e.KeyVals {
+			if _, err := fmt.Fprintf(w, " %s=%v", kv.Key, kv.Val); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiGray  = "\x1b[90m"
+)
+
+func levelColor(level string) string {
+	switch level {
+	case levelError.String():
+		return ansiRed
+	case levelInfo.String():
+		return ansiGreen
+	case levelDebug.String():
+		return ansiGray
+	default:
+		return ""
+	}
+}
+
+// ConsoleEncoder writes a Log for reading in a terminal: a header line
+// followed by one indented, colorized line per entry with its KeyVals
+// appended.
+type ConsoleEncoder struct{}
+
+func (ConsoleEncoder) Encode(w io.Writer, log Log) error {
+	if _, err := fmt.Fprintf(w, "%s %s %s status=%d duration=%d\n",
+		log.Date.Format(time.RFC3339), log.Kind, log.ThreadId, log.Status, log.Duration,
+	); err != nil {
+		return err
+	}
+	for _, e := range log.Entries {
+		if _, err := fmt.Fprintf(w, "  %s%-5s%s %s:%d %s",
+			levelColor(e.Level), e.Level, ansiReset, e.File, e.Line, e.Message,
+		); err != nil {
+			return err
+		}
+		for _, kv := range e.KeyVals {
+			if _, err := fmt.Fprintf(w, " %s=%v", kv.Key, kv.Val); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
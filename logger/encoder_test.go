@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogfmtEncoderEmptyLog(t *testing.T) {
+	var buf bytes.Buffer
+	log := Log{ThreadId: "thread-1", Kind: kindRequest, Route: "/widgets", Status: 200, Duration: 5}
+
+	if err := (LogfmtEncoder{}).Encode(&buf, log); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "thread_id=thread-1") || !strings.Contains(out, "status=200") {
+		t.Fatalf("Encode() of an entry-less Log produced no summary line: %q", out)
+	}
+}
+
+func TestLogfmtEncoderWithEntries(t *testing.T) {
+	var buf bytes.Buffer
+	log := Log{
+		ThreadId: "thread-1",
+		Kind:     kindRequest,
+		Route:    "/widgets",
+		Status:   200,
+		Duration: 5,
+		Entries: []*Entry{
+			{Level: "Info", Message: "working", File: "f.go", Line: 10},
+		},
+	}
+
+	if err := (LogfmtEncoder{}).Encode(&buf, log); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	out := buf.String()
+	if strings.Count(out, "\n") != 1 {
+		t.Fatalf("Encode() with one entry wrote %d lines, want 1: %q", strings.Count(out, "\n"), out)
+	}
+	if !strings.Contains(out, "msg=\"working\"") {
+		t.Fatalf("Encode() missing entry message: %q", out)
+	}
+}
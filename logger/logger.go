@@ -1,10 +1,12 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -32,6 +34,12 @@ func (lk logKind) String() string {
 	return lk.name
 }
 
+// MarshalJSON lets logKind pass through JSONEncoder as its name rather
+// than as a struct with an unexported field.
+func (lk logKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lk.name)
+}
+
 var (
 	kindRequest = logKind{"request"}
 	kindSession = logKind{"session"}
@@ -44,7 +52,16 @@ type Log struct {
 	Route    string
 	Status   int
 	Duration int
-	Entries  []*Entry
+	// Reason is set when a Log was produced by a context.Context
+	// being cancelled or timing out before End was called, rather
+	// than by a normal End call. It is "cancelled", "timeout",
+	// "forced" for a thread flushed through AdminHandler's DELETE
+	// route, or empty for a normal completion.
+	Reason string
+	// Dropped is how many non-Error entries the MaxEntries cap or
+	// RateLimit caused to be discarded for this thread before it ended.
+	Dropped int
+	Entries []*Entry
 }
 
 type Entry struct {
@@ -80,7 +97,84 @@ type Logger struct {
 	OnError        func(Log)
 	DisableDebug   bool
 	DisableRuntime bool
-	logs           sync.Map
+
+	// MaxEntries caps how many entries a single thread may buffer:
+	// once it holds that many, further non-Error entries are dropped.
+	// Zero means unlimited. Errors are always kept and count toward
+	// the cap. The count of entries dropped this way is reported on
+	// the final Log as Dropped.
+	MaxEntries int
+
+	// SampleRate, when greater than one, keeps only every SampleRate'th
+	// Info/Debug entry per thread; all Errors are kept unconditionally.
+	// Zero or one keeps every entry.
+	SampleRate int
+
+	// RateLimit, if set, is consulted for every Info/Debug entry before
+	// it is buffered; an entry refused by the bucket for its level is
+	// dropped and counted in the final Log's Dropped, without counting
+	// toward MaxEntries or SampleRate.
+	RateLimit *RateLimiter
+
+	logs   sync.Map
+	opened sync.Map
+
+	// watched holds a claim flag (*int32, 0 or 1) per threadId with an
+	// active WatchContext, so that a context deadline/cancellation
+	// firing at the same moment a handler calls End/EndCtx can't
+	// double-emit the terminal Log: whichever side wins the
+	// compare-and-swap produces it, the loser returns immediately.
+	// Threads with no WatchContext registered are never raced, so they
+	// have no entry here.
+	watched sync.Map
+
+	// threadMeta records the kind and name each Session was opened
+	// under, keyed by threadId, so something outside the normal
+	// Logger/Session call chain (AdminHandler's force-end) can produce
+	// an accurate terminal Log instead of guessing kindRequest.
+	// Request threads have no entry here.
+	threadMeta sync.Map
+
+	sinksMu sync.Mutex
+	sinks   []Sink
+
+	metricEmitted       atomic.Int64
+	metricDropped       atomic.Int64
+	metricSampled       atomic.Int64
+	metricActiveThreads atomic.Int64
+}
+
+// threadBuf is the value stored once per threadId in Logger.logs. It
+// replaces the previous load/append/store dance, under which every
+// call re-stored a reslice back into the map, with a single entry that
+// is mutated in place behind its own mutex.
+type threadBuf struct {
+	mu      sync.Mutex
+	entries []*Entry
+	dropped int
+	kept    int
+}
+
+// AddSink registers sink to receive every Log produced by end(), in
+// addition to OnLogEvent and OnError, which are kept as-is for
+// backward compatibility. Wrap sink with MinLevel to only forward
+// entries at or above a given level.
+func (l *Logger) AddSink(sink Sink) {
+	l.sinksMu.Lock()
+	l.sinks = append(l.sinks, sink)
+	l.sinksMu.Unlock()
+}
+
+// writeSinks dispatches log to every registered sink. Sinks are
+// best-effort, same as OnLogEvent: a failing destination shouldn't
+// block or crash the caller that triggered the flush.
+func (l *Logger) writeSinks(log Log) {
+	l.sinksMu.Lock()
+	sinks := l.sinks
+	l.sinksMu.Unlock()
+	for _, sink := range sinks {
+		_ = sink.Write(log)
+	}
 }
 
 func (l *Logger) Info(reqId, msg string) *Entry {
@@ -104,7 +198,7 @@ func (l *Logger) DebugF(reqId, format string, a ...interface{}) *Entry {
 }
 
 func (l *Logger) End(reqId, route string, status, duration int) {
-	l.end(kindRequest, reqId, route, status, duration)
+	l.end(kindRequest, reqId, route, status, duration, "")
 }
 
 func (l *Logger) NewId() string {
@@ -121,7 +215,7 @@ func (l *Logger) logUUIDError(err error) {
 		Level:   levelError.String(),
 		Message: "couldn't generate UUID for logger thread: " + err.Error(),
 	}
-	l.insertEntry(e)
+	l.insertEntry(levelError, e)
 }
 
 func (l *Logger) getCallSite() (string, string, int) {
@@ -167,32 +261,117 @@ func (l *Logger) logEntry(level logLevel, threadId, msg string) *Entry {
 		Message:  msg,
 	}
 
-	l.insertEntry(e)
+	l.insertEntry(level, e)
 
 	return e
 }
 
-func (l *Logger) insertEntry(e *Entry) {
+// logEntryFromPC is logEntry for callers that already have a program
+// counter for the real call site, such as slog.Record.PC, rather than
+// one sitting a fixed number of frames above insertEntry the way every
+// other entry point in this file does.
+func (l *Logger) logEntryFromPC(level logLevel, threadId, msg string, pc uintptr) *Entry {
 
-	entries, ok := l.logs.Load(e.ThreadId)
-	if !ok {
-		l.logs.Store(e.ThreadId, []*Entry{e})
-		return
+	if level == levelDebug && l.DisableDebug {
+		return &Entry{}
+	}
+
+	var function, file string
+	var line int
+
+	if !l.DisableRuntime && pc != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		function = frame.Function
+		if idx := strings.LastIndex(function, "/"); idx != -1 {
+			function = function[idx+1:]
+		}
+		file = frame.File
+		line = frame.Line
 	}
 
-	// We know the map only has this type as values.
-	ee := entries.([]*Entry)
-	ee = append(ee, e)
-	l.logs.Store(e.ThreadId, ee)
+	e := &Entry{
+		ThreadId: threadId,
+		Level:    level.String(),
+		Function: function,
+		File:     file,
+		Line:     line,
+		Message:  msg,
+	}
+
+	l.insertEntry(level, e)
+
+	return e
+}
+
+func (l *Logger) insertEntry(level logLevel, e *Entry) {
+
+	v, loaded := l.logs.LoadOrStore(e.ThreadId, &threadBuf{})
+	buf := v.(*threadBuf)
+	if !loaded {
+		l.opened.Store(e.ThreadId, time.Now())
+		l.metricActiveThreads.Add(1)
+	}
+
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	// Errors are always kept: the rate limiter, sampling, and the
+	// MaxEntries cap all only apply to Info/Debug, so a hot error
+	// loop can never lose the entries it exists to surface.
+	if level != levelError {
+		if l.RateLimit != nil && !l.RateLimit.allow(level) {
+			buf.dropped++
+			l.metricDropped.Add(1)
+			return
+		}
+		if l.SampleRate > 1 {
+			buf.kept++
+			if buf.kept%l.SampleRate != 0 {
+				l.metricSampled.Add(1)
+				return
+			}
+		}
+		if l.MaxEntries > 0 && len(buf.entries) >= l.MaxEntries {
+			buf.dropped++
+			l.metricDropped.Add(1)
+			return
+		}
+	}
+
+	buf.entries = append(buf.entries, e)
+	l.metricEmitted.Add(1)
 }
 
-func (l *Logger) end(kind logKind, threadId, route string, status, duration int) {
+func (l *Logger) end(kind logKind, threadId, route string, status, duration int, reason string) {
+
+	// If a WatchContext is active for threadId, only the first of it
+	// and this call may proceed: whichever loses the compare-and-swap
+	// returns immediately instead of producing a second, spurious Log.
+	// There are at most two callers ever for a given threadId (the
+	// explicit End/EndCtx/Session.End call and the ctx watcher), so the
+	// loser is always the last one to arrive and can safely forget the
+	// claim flag.
+	if v, ok := l.watched.Load(threadId); ok {
+		claimed := v.(*int32)
+		if !atomic.CompareAndSwapInt32(claimed, 0, 1) {
+			l.watched.Delete(threadId)
+			return
+		}
+	}
 
 	var ee []*Entry
-	entries, ok := l.logs.Load(threadId)
+	var dropped int
+	v, ok := l.logs.LoadAndDelete(threadId)
 	if ok {
-		l.logs.Delete(threadId)
-		ee = entries.([]*Entry)
+		l.opened.Delete(threadId)
+		l.threadMeta.Delete(threadId)
+		l.metricActiveThreads.Add(-1)
+
+		buf := v.(*threadBuf)
+		buf.mu.Lock()
+		ee = buf.entries
+		dropped = buf.dropped
+		buf.mu.Unlock()
 	}
 
 	// Unlike requests there's no value in logging a
@@ -209,9 +388,13 @@ func (l *Logger) end(kind logKind, threadId, route string, status, duration int)
 		Route:    route,
 		Status:   status,
 		Duration: duration,
+		Reason:   reason,
+		Dropped:  dropped,
 		Entries:  ee,
 	}
 
+	l.writeSinks(log)
+
 	var errs []*Entry
 	if l.OnError != nil {
 		for _, e := range ee {
@@ -238,24 +421,34 @@ type Session struct {
 }
 
 func (l *Logger) Sess(name string) *Session {
+	id := l.NewId()
+	l.threadMeta.Store(id, threadMeta{kind: kindSession, route: name})
 	return &Session{
-		id:     l.NewId(),
+		id:     id,
 		name:   name,
 		logger: l,
 	}
 }
 
+// threadMeta is the value stored in Logger.threadMeta.
+type threadMeta struct {
+	kind  logKind
+	route string
+}
+
 func (s *Session) SeenError() bool {
 
-	var ee []*Entry
-	entries, ok := s.logger.logs.Load(s.id)
+	v, ok := s.logger.logs.Load(s.id)
 	if !ok {
 		return false
 	}
-	ee = entries.([]*Entry)
+	buf := v.(*threadBuf)
+
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
 
-	for _, e := range ee {
-		if e.Level == "Error" {
+	for _, e := range buf.entries {
+		if e.Level == levelError.String() {
 			return true
 		}
 	}
@@ -283,5 +476,5 @@ func (s *Session) DebugF(format string, a ...interface{}) *Entry {
 }
 
 func (s *Session) End() {
-	s.logger.end(kindSession, s.id, s.name, 0, 0)
+	s.logger.end(kindSession, s.id, s.name, 0, 0, "")
 }
\ No newline at end of file
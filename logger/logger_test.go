@@ -0,0 +1,89 @@
+package logger
+
+import "testing"
+
+func TestRateLimitExemptsErrors(t *testing.T) {
+	l := &Logger{RateLimit: NewRateLimiter(0, 1)}
+
+	l.Info("thread-1", "first")  // consumes the only Info token
+	l.Info("thread-1", "second") // should be rate-limited and dropped
+
+	for i := 0; i < 5; i++ {
+		l.Error("thread-1", "boom")
+	}
+
+	v, ok := l.logs.Load("thread-1")
+	if !ok {
+		t.Fatal("thread-1 has no buffered entries")
+	}
+	buf := v.(*threadBuf)
+
+	var errs, infos int
+	for _, e := range buf.entries {
+		switch e.Level {
+		case levelError.String():
+			errs++
+		case levelInfo.String():
+			infos++
+		}
+	}
+	if errs != 5 {
+		t.Fatalf("got %d Error entries, want all 5 kept despite the rate limit", errs)
+	}
+	if infos != 1 {
+		t.Fatalf("got %d Info entries, want exactly 1 (the rest rate-limited)", infos)
+	}
+
+	m := l.Metrics()
+	if m.Dropped == 0 {
+		t.Fatal("Metrics().Dropped = 0, want the rate-limited Info entry counted")
+	}
+}
+
+func TestMaxEntriesDropsOnlyNonErrors(t *testing.T) {
+	l := &Logger{MaxEntries: 1}
+
+	l.Info("thread-1", "kept")
+	l.Info("thread-1", "dropped")
+	l.Error("thread-1", "always kept")
+
+	v, _ := l.logs.Load("thread-1")
+	buf := v.(*threadBuf)
+
+	if len(buf.entries) != 2 {
+		t.Fatalf("got %d entries, want 2 (1 Info + 1 Error)", len(buf.entries))
+	}
+	if buf.dropped != 1 {
+		t.Fatalf("buf.dropped = %d, want 1", buf.dropped)
+	}
+
+	l.End("thread-1", "/widgets", 200, 1)
+}
+
+func TestSampleRateKeepsAllErrors(t *testing.T) {
+	l := &Logger{SampleRate: 3}
+
+	for i := 0; i < 5; i++ {
+		l.Info("thread-1", "tick")
+		l.Error("thread-1", "boom")
+	}
+
+	v, _ := l.logs.Load("thread-1")
+	buf := v.(*threadBuf)
+
+	var errs, infos int
+	for _, e := range buf.entries {
+		switch e.Level {
+		case levelError.String():
+			errs++
+		case levelInfo.String():
+			infos++
+		}
+	}
+	if errs != 5 {
+		t.Fatalf("got %d Error entries, want all 5 kept regardless of SampleRate", errs)
+	}
+	if infos == 5 {
+		t.Fatal("got all 5 Info entries, want sampling to have dropped some")
+	}
+}
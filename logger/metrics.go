@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// Metrics is a point-in-time snapshot of a Logger's Prometheus-style
+// counters, suitable for scraping from an accessor exposed on a
+// metrics endpoint.
+type Metrics struct {
+	Emitted       int64
+	Dropped       int64
+	Sampled       int64
+	ActiveThreads int64
+}
+
+// Metrics returns the current counters for l.
+func (l *Logger) Metrics() Metrics {
+	return Metrics{
+		Emitted:       l.metricEmitted.Load(),
+		Dropped:       l.metricDropped.Load(),
+		Sampled:       l.metricSampled.Load(),
+		ActiveThreads: l.metricActiveThreads.Load(),
+	}
+}
+
+// tokenBucket is a minimal token-bucket limiter: tokens refill at
+// ratePerSec up to burst, and allow reports whether a token was
+// available, consuming one if so.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	burst  float64
+	rate   float64
+	last   time.Time
+}
+
+func newTokenBucket(ratePerSec, burst float64) *tokenBucket {
+	return &tokenBucket{tokens: burst, burst: burst, rate: ratePerSec, last: time.Now()}
+}
+
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// RateLimiter caps how many Info/Debug entries per second each of
+// those levels may log, using an independent token bucket per level
+// so a hot Debug loop can't crowd out Info entries. It is never
+// consulted for Error, which insertEntry always keeps.
+type RateLimiter struct {
+	debug *tokenBucket
+	info  *tokenBucket
+}
+
+// NewRateLimiter builds a RateLimiter where each level may log up to
+// burst entries immediately and ratePerSec thereafter.
+func NewRateLimiter(ratePerSec, burst float64) *RateLimiter {
+	return &RateLimiter{
+		debug: newTokenBucket(ratePerSec, burst),
+		info:  newTokenBucket(ratePerSec, burst),
+	}
+}
+
+func (r *RateLimiter) allow(level logLevel) bool {
+	switch level {
+	case levelDebug:
+		return r.debug.allow()
+	case levelInfo:
+		return r.info.allow()
+	}
+	return true
+}
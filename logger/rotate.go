@@ -0,0 +1,133 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// RotatingFileSink encodes every Log with Enc and appends it to a file
+// that rotates once it exceeds MaxBytes or has been open longer than
+// MaxAge, whichever comes first. A zero MaxBytes or MaxAge disables
+// that trigger. Rotated segments are renamed with a timestamp suffix
+// and, if Gzip is set, compressed in place and the uncompressed
+// segment removed.
+type RotatingFileSink struct {
+	Path     string
+	MaxBytes int64
+	MaxAge   time.Duration
+	Gzip     bool
+	Enc      Encoder
+
+	mu     sync.Mutex
+	f      *os.File
+	size   int64
+	opened time.Time
+}
+
+func NewRotatingFileSink(path string, maxBytes int64, maxAge time.Duration, gzipRotated bool, enc Encoder) (*RotatingFileSink, error) {
+	s := &RotatingFileSink{
+		Path:     path,
+		MaxBytes: maxBytes,
+		MaxAge:   maxAge,
+		Gzip:     gzipRotated,
+		Enc:      enc,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *RotatingFileSink) open() error {
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.f = f
+	s.size = info.Size()
+	s.opened = time.Now()
+	return nil
+}
+
+func (s *RotatingFileSink) Write(log Log) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.shouldRotate() {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := s.Enc.Encode(&buf, log); err != nil {
+		return err
+	}
+	n, err := s.f.Write(buf.Bytes())
+	s.size += int64(n)
+	return err
+}
+
+func (s *RotatingFileSink) shouldRotate() bool {
+	if s.MaxBytes > 0 && s.size >= s.MaxBytes {
+		return true
+	}
+	if s.MaxAge > 0 && time.Since(s.opened) >= s.MaxAge {
+		return true
+	}
+	return false
+}
+
+func (s *RotatingFileSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return err
+	}
+	rotated := s.Path + "." + time.Now().Format("20060102T150405")
+	if err := os.Rename(s.Path, rotated); err != nil {
+		return err
+	}
+	if s.Gzip {
+		if err := gzipFile(rotated); err != nil {
+			return err
+		}
+	}
+	return s.open()
+}
+
+func (s *RotatingFileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFileSinkRotatesAndGzips(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	sink, err := NewRotatingFileSink(path, 10, 0, true, JSONEncoder{})
+	if err != nil {
+		t.Fatalf("NewRotatingFileSink() error = %v", err)
+	}
+	defer sink.Close()
+
+	// Each encoded Log is well over 10 bytes, so the second write
+	// should trigger a rotation of the first segment.
+	for i := 0; i < 2; i++ {
+		if err := sink.Write(Log{ThreadId: "thread-1", Route: "/widgets"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+
+	var sawGzippedSegment bool
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) == ".gz" {
+			sawGzippedSegment = true
+		}
+	}
+	if !sawGzippedSegment {
+		t.Fatalf("expected a gzipped rotated segment alongside %q, got %v", path, entries)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the live log file to still exist after rotation: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package logger
+
+import "io"
+
+// Sink is a destination a Logger can flush completed Logs to, as an
+// alternative to handling them one-off in OnLogEvent. Write is called
+// once per Log, from end(), so it should not block for long.
+type Sink interface {
+	Write(Log) error
+	Close() error
+}
+
+// levelRank orders levels so sinks can filter by a minimum severity.
+var levelRank = map[string]int{
+	levelDebug.String(): 0,
+	levelInfo.String():  1,
+	levelError.String(): 2,
+}
+
+// WriterSink encodes every Log with enc and writes the result to w.
+type WriterSink struct {
+	w   io.Writer
+	enc Encoder
+}
+
+func NewWriterSink(w io.Writer, enc Encoder) *WriterSink {
+	return &WriterSink{w: w, enc: enc}
+}
+
+func (s *WriterSink) Write(log Log) error {
+	return s.enc.Encode(s.w, log)
+}
+
+func (s *WriterSink) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// FanOutSink writes every Log to each of its children. It continues
+// past a child's error so one failing destination doesn't stop the
+// rest, but still reports the last error it saw.
+type FanOutSink struct {
+	sinks []Sink
+}
+
+func NewFanOutSink(sinks ...Sink) *FanOutSink {
+	return &FanOutSink{sinks: sinks}
+}
+
+func (s *FanOutSink) Write(log Log) error {
+	var err error
+	for _, sink := range s.sinks {
+		if e := sink.Write(log); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func (s *FanOutSink) Close() error {
+	var err error
+	for _, sink := range s.sinks {
+		if e := sink.Close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// MinLevel wraps sink so it only receives entries at lvl or above,
+// using the same partitioning logic Logger already applies for
+// OnError. A Log with no matching entries after filtering is not
+// passed through at all.
+func MinLevel(lvl string, sink Sink) Sink {
+	return &levelFilterSink{rank: levelRank[lvl], sink: sink}
+}
+
+type levelFilterSink struct {
+	rank int
+	sink Sink
+}
+
+func (s *levelFilterSink) Write(log Log) error {
+	var ee []*Entry
+	for _, e := range log.Entries {
+		if levelRank[e.Level] >= s.rank {
+			ee = append(ee, e)
+		}
+	}
+	if ee == nil {
+		return nil
+	}
+	log.Entries = ee
+	return s.sink.Write(log)
+}
+
+func (s *levelFilterSink) Close() error {
+	return s.sink.Close()
+}
@@ -0,0 +1,71 @@
+package logger
+
+import "testing"
+
+type recordingSink struct {
+	logs   []Log
+	closed bool
+}
+
+func (s *recordingSink) Write(log Log) error {
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestMinLevelFiltersEntries(t *testing.T) {
+	rec := &recordingSink{}
+	sink := MinLevel(levelError.String(), rec)
+
+	log := Log{
+		ThreadId: "thread-1",
+		Entries: []*Entry{
+			{Level: levelInfo.String(), Message: "fyi"},
+			{Level: levelError.String(), Message: "boom"},
+		},
+	}
+
+	if err := sink.Write(log); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if len(rec.logs) != 1 {
+		t.Fatalf("got %d Logs forwarded, want 1", len(rec.logs))
+	}
+	if got := rec.logs[0].Entries; len(got) != 1 || got[0].Level != levelError.String() {
+		t.Fatalf("forwarded entries = %+v, want only the Error entry", got)
+	}
+
+	// A Log with nothing at or above the minimum level shouldn't be
+	// forwarded at all.
+	rec.logs = nil
+	if err := sink.Write(Log{Entries: []*Entry{{Level: levelInfo.String()}}}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if len(rec.logs) != 0 {
+		t.Fatalf("got %d Logs forwarded for an all-below-minimum Log, want 0", len(rec.logs))
+	}
+}
+
+func TestFanOutSinkWritesToAllChildren(t *testing.T) {
+	a, b := &recordingSink{}, &recordingSink{}
+	fanOut := NewFanOutSink(a, b)
+
+	log := Log{ThreadId: "thread-1"}
+	if err := fanOut.Write(log); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if len(a.logs) != 1 || len(b.logs) != 1 {
+		t.Fatalf("expected both children to receive the Log, got a=%d b=%d", len(a.logs), len(b.logs))
+	}
+
+	if err := fanOut.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !a.closed || !b.closed {
+		t.Fatal("Close() did not close every child sink")
+	}
+}
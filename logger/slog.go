@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// SlogHandler adapts a Logger to slog.Handler, funneling records into
+// this module's per-thread buffering instead of slog's own output,
+// preserving slog.Attr pairs as KeyVals and mapping slog.Level onto
+// Info/Error/Debug. Construct one with NewSlogHandler or
+// NewSlogHandlerCtx rather than building it directly.
+type SlogHandler struct {
+	logger   *Logger
+	threadId string
+	fromCtx  bool
+	attrs    []slog.Attr
+	groups   []string
+}
+
+// NewSlogHandler returns a slog.Handler that funnels every record into
+// l under threadId.
+func NewSlogHandler(l *Logger, threadId string) *SlogHandler {
+	return &SlogHandler{logger: l, threadId: threadId}
+}
+
+// NewSlogHandlerCtx is NewSlogHandler for code that only learns its
+// threadId from a context.Context previously tagged by NewContext.
+func NewSlogHandlerCtx(l *Logger) *SlogHandler {
+	return &SlogHandler{logger: l, fromCtx: true}
+}
+
+// NewSlogLogger returns a *slog.Logger backed by l under threadId, for
+// handing to libraries that expect the standard library's structured
+// logger but should still have their output grouped into l's
+// per-thread buffering.
+func NewSlogLogger(l *Logger, threadId string) *slog.Logger {
+	return slog.New(NewSlogHandler(l, threadId))
+}
+
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if level < slog.LevelInfo {
+		return !h.logger.DisableDebug
+	}
+	return true
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+
+	threadId := h.threadId
+	if h.fromCtx {
+		threadId, _ = FromContext(ctx)
+	}
+
+	var level logLevel
+	switch {
+	case r.Level >= slog.LevelError:
+		level = levelError
+	case r.Level >= slog.LevelInfo:
+		level = levelInfo
+	default:
+		level = levelDebug
+	}
+
+	// Use r.PC, which slog captured at the original call site, rather
+	// than this package's usual fixed-depth runtime.Caller trick —
+	// Handle sits behind slog's own dispatch, at a different stack
+	// depth than a direct Logger.Info/Error/Debug call.
+	e := h.logger.logEntryFromPC(level, threadId, r.Message, r.PC)
+
+	for _, a := range h.attrs {
+		e.Data(slogKey(h.keyName(a.Key)), a.Value.Any())
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		e.Data(slogKey(h.keyName(a.Key)), a.Value.Any())
+		return true
+	})
+
+	return nil
+}
+
+// keyName prefixes k with any groups opened via WithGroup, the same
+// dotted-path convention slog's own handlers use for flattened output.
+func (h *SlogHandler) keyName(k string) string {
+	if len(h.groups) == 0 {
+		return k
+	}
+	return strings.Join(h.groups, ".") + "." + k
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	cp := *h
+	cp.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &cp
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	cp := *h
+	cp.groups = append(append([]string{}, h.groups...), name)
+	return &cp
+}
+
+// slogKey lets a plain slog attribute key satisfy fmt.Stringer so it
+// can be passed to Entry.Data.
+type slogKey string
+
+func (k slogKey) String() string {
+	return string(k)
+}
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogHandlerCallSiteAttribution(t *testing.T) {
+	l := &Logger{}
+	sl := NewSlogLogger(l, "thread-1")
+
+	sl.Info("hello") // this call site should be recorded, not Handle's
+
+	v, ok := l.logs.Load("thread-1")
+	if !ok {
+		t.Fatal("thread-1 has no buffered entries")
+	}
+	buf := v.(*threadBuf)
+	if len(buf.entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(buf.entries))
+	}
+
+	e := buf.entries[0]
+	if strings.Contains(e.File, "slog.go") {
+		t.Fatalf("File = %q, recorded the adapter's own call site instead of the caller's", e.File)
+	}
+	if !strings.Contains(e.File, "slog_test.go") {
+		t.Fatalf("File = %q, want it to contain slog_test.go", e.File)
+	}
+}
+
+func TestSlogHandlerCtxThreadId(t *testing.T) {
+	l := &Logger{}
+	sl := slog.New(NewSlogHandlerCtx(l))
+
+	ctx := NewContext(context.Background(), "thread-2")
+	sl.InfoContext(ctx, "hello")
+
+	if _, ok := l.logs.Load("thread-2"); !ok {
+		t.Fatal("expected an entry buffered under thread-2")
+	}
+}